@@ -0,0 +1,34 @@
+// Package consensus pulls block-sealing out of the chain itself so a node
+// can run either classic proof-of-work or a stake-weighted round-robin
+// proposer behind the same Engine interface.
+package consensus
+
+// Block is the minimal view of backend.Block an Engine needs. The concrete
+// Block type satisfies this structurally (see the Get*/Set* methods on
+// *Block), so this package never has to import the main package.
+type Block interface {
+	GetIndex() int
+	GetPrevHash() string
+	GetHash() string
+	SetHash(hash string)
+	GetNonce() int
+	SetNonce(nonce int)
+	GetMiner() string
+	SetMiner(address string)
+	GetRound() uint64
+	SetRound(round uint64)
+	CalculateHash() string
+}
+
+// Engine seals new blocks and verifies sealed ones, independent of whatever
+// scheme (PoW, PoS, ...) decides who gets to propose a block and how their
+// proposal is authenticated.
+type Engine interface {
+	// Seal proposes block: it fills in whatever the scheme requires (a
+	// PoW nonce, a PoS proposer address) and sets the final Hash.
+	Seal(block Block) error
+	// VerifySeal reports whether block's seal is valid under this engine.
+	VerifySeal(block Block) bool
+	// Author returns the address credited with having proposed block.
+	Author(block Block) (string, error)
+}
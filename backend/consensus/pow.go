@@ -0,0 +1,40 @@
+package consensus
+
+import "strings"
+
+// ProofOfWork is the original mining scheme: a block is sealed once its
+// hash has Difficulty leading zero hex digits.
+type ProofOfWork struct {
+	Difficulty int
+}
+
+// NewProofOfWork returns a PoW engine targeting the given difficulty.
+func NewProofOfWork(difficulty int) *ProofOfWork {
+	return &ProofOfWork{Difficulty: difficulty}
+}
+
+// Seal brute-forces the nonce until CalculateHash produces Difficulty
+// leading zeros.
+func (p *ProofOfWork) Seal(block Block) error {
+	target := strings.Repeat("0", p.Difficulty)
+
+	for {
+		block.SetHash(block.CalculateHash())
+		if strings.HasPrefix(block.GetHash(), target) {
+			return nil
+		}
+		block.SetNonce(block.GetNonce() + 1)
+	}
+}
+
+// VerifySeal checks the hash both matches CalculateHash and meets the
+// difficulty target.
+func (p *ProofOfWork) VerifySeal(block Block) bool {
+	target := strings.Repeat("0", p.Difficulty)
+	return strings.HasPrefix(block.GetHash(), target) && block.GetHash() == block.CalculateHash()
+}
+
+// Author returns whoever the miner credited itself as.
+func (p *ProofOfWork) Author(block Block) (string, error) {
+	return block.GetMiner(), nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// getBalance handler returns the current account balance for an address.
+func getBalance(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	address := mux.Vars(r)["address"]
+
+	chainMu.Lock()
+	balance := blockchain.balanceOf(address).String()
+	chainMu.Unlock()
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"address": address,
+		"balance": balance,
+	})
+}
+
+// getMempoolStats handler summarizes the pending pool: how many
+// transactions are waiting and the total gas fee they're offering.
+func getMempoolStats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	chainMu.Lock()
+	pending := append([]Transaction(nil), pendingTransactions...)
+	chainMu.Unlock()
+
+	totalGas := big.NewInt(0)
+	for _, tx := range pending {
+		if gas, ok := parseUint(tx.GasFee); ok {
+			totalGas.Add(totalGas, gas)
+		}
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"pending_count": len(pending),
+		"total_gas_fee": totalGas.String(),
+	})
+}
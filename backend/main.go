@@ -4,20 +4,43 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
+	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
+
+	"github.com/Muhammadmuneebulhaq/MuneebBlockchain/backend/consensus"
+	"github.com/Muhammadmuneebulhaq/MuneebBlockchain/backend/storage"
+	"github.com/Muhammadmuneebulhaq/MuneebBlockchain/backend/wallet"
 )
 
-// Transaction represents a transaction in the blockchain
+// Transaction represents a transaction in the blockchain. PubKey and
+// Signature authenticate From: PubKey must hash to From (see wallet.Address)
+// and Signature must verify over Hash().
 type Transaction struct {
-	ID     string `json:"id"`
+	ID        string `json:"id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Amount    string `json:"amount"`
+	Data      string `json:"data"`
+	GasFee    string `json:"gas_fee"`
+	PubKey    string `json:"pub_key,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// canonicalPayload is what actually gets signed: every field a sender
+// commits to except ID (assigned by the node) and PubKey/Signature
+// themselves.
+type canonicalPayload struct {
 	From   string `json:"from"`
 	To     string `json:"to"`
 	Amount string `json:"amount"`
@@ -25,6 +48,45 @@ type Transaction struct {
 	GasFee string `json:"gas_fee"`
 }
 
+// Hash returns the digest a wallet signs and Verify checks against.
+func (tx *Transaction) Hash() []byte {
+	data, _ := json.Marshal(canonicalPayload{
+		From:   tx.From,
+		To:     tx.To,
+		Amount: tx.Amount,
+		Data:   tx.Data,
+		GasFee: tx.GasFee,
+	})
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// Verify checks that PubKey hashes to From and that Signature is a valid
+// signature over Hash(). System transactions (genesis, block rewards) are
+// exempt since they don't originate from a wallet.
+func (tx *Transaction) Verify() bool {
+	if tx.From == "system" {
+		return true
+	}
+	if tx.PubKey == "" || tx.Signature == "" {
+		return false
+	}
+
+	pubKey, err := hex.DecodeString(tx.PubKey)
+	if err != nil {
+		return false
+	}
+	signature, err := hex.DecodeString(tx.Signature)
+	if err != nil {
+		return false
+	}
+	if wallet.Address(pubKey) != tx.From {
+		return false
+	}
+
+	return wallet.Verify(pubKey, tx.Hash(), signature)
+}
+
 
 // MerkleNode represents a node in the Merkle tree
 type MerkleNode struct {
@@ -42,16 +104,29 @@ type Block struct {
 	Hash         string        `json:"hash"`
 	Nonce        int           `json:"nonce"`
 	MerkleRoot   string        `json:"merkle_root"`
+	Miner        string        `json:"miner"`
+	Round        uint64        `json:"round"`
 }
 
-// Blockchain represents the blockchain
+// Blockchain represents the blockchain. Balances is the account-state layer
+// derived by replaying every block's transactions plus miner rewards; it is
+// never read from or written to directly outside of ApplyBlock/RebuildBalances.
 type Blockchain struct {
-	Blocks     []Block `json:"blocks"`
-	Difficulty int     `json:"difficulty"`
+	Blocks     []Block             `json:"blocks"`
+	Difficulty int                 `json:"difficulty"`
+	Balances   map[string]*big.Int `json:"balances"`
 }
 
 var blockchain Blockchain
 var pendingTransactions []Transaction // Global pool for pending transactions
+var store *storage.Store
+
+// chainMu serializes every read and write of blockchain and
+// pendingTransactions. HTTP handlers run one goroutine per request and p2p
+// gossip callbacks (network.go) run on their own subscription goroutines,
+// so both sides must go through this lock instead of touching the globals
+// directly.
+var chainMu sync.Mutex
 
 // NewMerkleNode creates a new Merkle tree node
 func NewMerkleNode(left, right *MerkleNode, data []byte) *MerkleNode {
@@ -72,41 +147,50 @@ func NewMerkleNode(left, right *MerkleNode, data []byte) *MerkleNode {
 	return &node
 }
 
-// NewMerkleTree creates a new Merkle tree from transactions
-func NewMerkleTree(transactions []Transaction) *MerkleNode {
-	var nodes []MerkleNode
+// MerkleTree wraps a Merkle tree built from a block's transactions. Unlike
+// the bare root MerkleNode, it keeps every level around so a leaf's
+// inclusion proof can be walked back out later.
+type MerkleTree struct {
+	Root         *MerkleNode
+	Transactions []Transaction // original, unpadded, in leaf order
+	levels       [][]*MerkleNode
+}
+
+// NewMerkleTree creates a new Merkle tree from transactions.
+func NewMerkleTree(transactions []Transaction) *MerkleTree {
+	var leaves []*MerkleNode
 
 	// Create leaf nodes for each transaction
 	for _, tx := range transactions {
 		data, _ := json.Marshal(tx)
-		node := NewMerkleNode(nil, nil, data)
-		nodes = append(nodes, *node)
+		leaves = append(leaves, NewMerkleNode(nil, nil, data))
 	}
 
 	// If no transactions, create a single node with empty data
-	if len(nodes) == 0 {
-		node := NewMerkleNode(nil, nil, []byte(""))
-		return node
+	if len(leaves) == 0 {
+		leaves = []*MerkleNode{NewMerkleNode(nil, nil, []byte(""))}
 	}
 
+	levels := [][]*MerkleNode{leaves}
+	current := leaves
+
 	// Build the tree bottom-up
-	for len(nodes) > 1 {
-		var level []MerkleNode
+	for len(current) > 1 {
+		var level []*MerkleNode
 
-		for i := 0; i < len(nodes); i += 2 {
-			if i+1 < len(nodes) {
-				node := NewMerkleNode(&nodes[i], &nodes[i+1], nil)
-				level = append(level, *node)
+		for i := 0; i < len(current); i += 2 {
+			if i+1 < len(current) {
+				level = append(level, NewMerkleNode(current[i], current[i+1], nil))
 			} else {
 				// If odd number of nodes, duplicate the last one
-				node := NewMerkleNode(&nodes[i], &nodes[i], nil)
-				level = append(level, *node)
+				level = append(level, NewMerkleNode(current[i], current[i], nil))
 			}
 		}
-		nodes = level
+		levels = append(levels, level)
+		current = level
 	}
 
-	return &nodes[0]
+	return &MerkleTree{Root: current[0], Transactions: transactions, levels: levels}
 }
 
 // CalculateHash calculates the hash of a block
@@ -133,6 +217,20 @@ func (b *Block) MineBlock(difficulty int) {
 	}
 }
 
+// The accessors below let *Block satisfy consensus.Block structurally, so
+// the consensus package can seal and verify blocks without importing this
+// package.
+func (b *Block) GetIndex() int         { return b.Index }
+func (b *Block) GetPrevHash() string   { return b.PrevHash }
+func (b *Block) GetHash() string       { return b.Hash }
+func (b *Block) SetHash(hash string)   { b.Hash = hash }
+func (b *Block) GetNonce() int         { return b.Nonce }
+func (b *Block) SetNonce(nonce int)    { b.Nonce = nonce }
+func (b *Block) GetMiner() string      { return b.Miner }
+func (b *Block) SetMiner(miner string) { b.Miner = miner }
+func (b *Block) GetRound() uint64      { return b.Round }
+func (b *Block) SetRound(round uint64) { b.Round = round }
+
 // CreateGenesisBlock creates the first block in the blockchain
 func CreateGenesisBlock() Block {
 	genesisTransactions := []Transaction{
@@ -148,7 +246,7 @@ func CreateGenesisBlock() Block {
 
 
 	merkleTree := NewMerkleTree(genesisTransactions)
-	merkleRoot := hex.EncodeToString(merkleTree.Data)
+	merkleRoot := hex.EncodeToString(merkleTree.Root.Data)
 
 	genesisBlock := Block{
 		Index:        0,
@@ -158,19 +256,21 @@ func CreateGenesisBlock() Block {
 		Hash:         "",
 		Nonce:        0,
 		MerkleRoot:   merkleRoot,
+		Miner:        "system",
 	}
 
-	genesisBlock.MineBlock(2) // Lower difficulty for genesis block
+	genesisBlock.MineBlock(2) // Always bootstrapped with plain low-difficulty PoW, regardless of --consensus
 	return genesisBlock
 }
 
-// AddBlock adds a new block to the blockchain
-func (bc *Blockchain) AddBlock(transactions []Transaction) {
+// AddBlock mines a new block crediting minerAddress, persists it to the
+// store, and applies it to the account-state layer.
+func (bc *Blockchain) AddBlock(transactions []Transaction, minerAddress string) error {
 	prevBlock := bc.Blocks[len(bc.Blocks)-1]
 
 	// Create Merkle tree for transactions
 	merkleTree := NewMerkleTree(transactions)
-	merkleRoot := hex.EncodeToString(merkleTree.Data)
+	merkleRoot := hex.EncodeToString(merkleTree.Root.Data)
 
 	newBlock := Block{
 		Index:        prevBlock.Index + 1,
@@ -180,31 +280,141 @@ func (bc *Blockchain) AddBlock(transactions []Transaction) {
 		Hash:         "",
 		Nonce:        0,
 		MerkleRoot:   merkleRoot,
+		Miner:        minerAddress,
+	}
+
+	if err := sealBlock(&newBlock); err != nil {
+		return fmt.Errorf("chain: seal block: %w", err)
+	}
+
+	if err := persistBlock(newBlock); err != nil {
+		return fmt.Errorf("chain: persist block: %w", err)
 	}
 
-	newBlock.MineBlock(bc.Difficulty)
 	bc.Blocks = append(bc.Blocks, newBlock)
+	bc.ApplyBlock(newBlock)
+	return nil
+}
+
+// persistBlock writes a mined block through to the store, indexing it by
+// hash, height and the IDs of the transactions it contains.
+func persistBlock(block Block) error {
+	if store == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(block)
+	if err != nil {
+		return err
+	}
+
+	txIDs := make([]string, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		txIDs[i] = tx.ID
+	}
+
+	return store.SaveBlock(block.Hash, block.Index, txIDs, data)
 }
 
-// IsChainValid validates the blockchain
+// loadBlockchain rebuilds the blockchain by walking the n:<index> -> hash
+// index from height 0 until a gap is found. Returns false if nothing was
+// found, so the caller can fall back to creating a fresh genesis block.
+func loadBlockchain() (Blockchain, bool) {
+	if store == nil {
+		return Blockchain{}, false
+	}
+
+	var blocks []Block
+	for index := 0; ; index++ {
+		hash, err := store.HashByIndex(index)
+		if err != nil {
+			break
+		}
+		data, err := store.GetBlock(hash)
+		if err != nil {
+			break
+		}
+		var block Block
+		if err := json.Unmarshal(data, &block); err != nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+
+	if len(blocks) == 0 {
+		return Blockchain{}, false
+	}
+	chain := Blockchain{Blocks: blocks, Difficulty: 4}
+	chain.RebuildBalances()
+	return chain, true
+}
+
+// IsChainValid validates the blockchain: block linkage, seal verification
+// (PoW difficulty, PoS proposer schedule, ...) delegated to the active
+// consensus engine, and that every transaction was affordable when it was
+// mined. A chain failing any of these is rejected wholesale - including by
+// ReplaceChain, which only adopts a peer's chain once this returns true.
 func (bc *Blockchain) IsChainValid() bool {
+	// For PoS, seal-check against a validator set replayed block-by-block
+	// from bc.Blocks itself rather than the live activeEngine: bc.Blocks
+	// may be a candidate chain (e.g. a peer's, via ReplaceChain) that this
+	// node hasn't applied yet, so its engine wouldn't know about that
+	// chain's validators at all. Block i's seal only depends on
+	// validators registered in blocks before it, so the replay set is
+	// grown one block at a time, never including the block being checked.
+	verifySeal := verifyBlockSeal
+	var replay *consensus.ProofOfStake
+	_, isPoS := activeEngine.(*consensus.ProofOfStake)
+	if isPoS && len(bc.Blocks) > 0 {
+		replay = consensus.NewProofOfStake(nil)
+		applyValidatorRegistrations(replay, bc.Blocks[0])
+		verifySeal = func(block *Block) bool {
+			if !replay.HasValidators() {
+				return block.Hash == block.CalculateHash()
+			}
+			return replay.VerifySeal(block)
+		}
+	}
+
 	for i := 1; i < len(bc.Blocks); i++ {
 		currentBlock := bc.Blocks[i]
 		prevBlock := bc.Blocks[i-1]
 
-		if currentBlock.Hash != currentBlock.CalculateHash() {
+		if currentBlock.PrevHash != prevBlock.Hash {
 			return false
 		}
 
-		if currentBlock.PrevHash != prevBlock.Hash {
+		if !verifySeal(&currentBlock) {
 			return false
 		}
+
+		if isPoS {
+			applyValidatorRegistrations(replay, currentBlock)
+		}
 	}
+
+	if !validateChainBalances(bc.Blocks) {
+		return false
+	}
+
 	return true
 }
 
-// SearchBlockchain searches for data in the blockchain
+// SearchBlockchain searches for data in the blockchain. An exact
+// transaction-ID match is resolved directly through the store's tx index
+// instead of scanning every block.
 func (bc *Blockchain) SearchBlockchain(query string) []Block {
+	if store != nil {
+		if hash, err := store.BlockHashForTx(query); err == nil {
+			if data, err := store.GetBlock(hash); err == nil {
+				var block Block
+				if json.Unmarshal(data, &block) == nil {
+					return []Block{block}
+				}
+			}
+		}
+	}
+
 	var results []Block
 	query = strings.ToLower(query)
 
@@ -235,7 +445,12 @@ func (bc *Blockchain) SearchBlockchain(query string) []Block {
 // getPendingTransactions handler
 func getPendingTransactions(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(pendingTransactions)
+
+	chainMu.Lock()
+	pending := append([]Transaction(nil), pendingTransactions...)
+	chainMu.Unlock()
+
+	json.NewEncoder(w).Encode(pending)
 }
 
 // addTransaction handler now adds to pending pool
@@ -249,69 +464,143 @@ func addTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	for i, tx := range transactions {
+		if tx.From == "system" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("transaction %d: \"system\" is a reserved sender and cannot be submitted", i)})
+			return
+		}
+		if !tx.Verify() {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf("transaction %d: invalid or missing signature", i)})
+			return
+		}
+	}
+
 	// Add timestamp and a unique ID to each transaction
 	for i := range transactions {
 		transactions[i].ID = fmt.Sprintf("tx_%d_%d", time.Now().UnixNano(), i)
-		pendingTransactions = append(pendingTransactions, transactions[i])
+	}
+
+	chainMu.Lock()
+	pendingTransactions = append(pendingTransactions, transactions...)
+	pending := append([]Transaction(nil), pendingTransactions...)
+	chainMu.Unlock()
+
+	for _, tx := range transactions {
+		broadcastTx(tx)
 	}
 
 	response := map[string]interface{}{
 		"message":              "Transactions added to pending pool",
-		"pending_count":        len(pendingTransactions),
-		"pending_transactions": pendingTransactions,
+		"pending_count":        len(pending),
+		"pending_transactions": pending,
 	}
 
 	json.NewEncoder(w).Encode(response)
 }
 
-// mineBlock handler now mines selected transactions
+// mineRequest is the body expected by POST /api/mine. When TransactionIDs
+// is omitted, pending transactions are auto-selected by descending
+// GasFee/size, Ethereum-mempool style.
+type mineRequest struct {
+	Miner          string   `json:"miner"`
+	TransactionIDs []string `json:"transaction_ids,omitempty"`
+}
+
+// mineBlock handler mines either the requested transaction IDs or, if none
+// are given, auto-selects pending transactions by descending gas-fee-per-byte.
+// Candidates are dropped if their signature doesn't verify or the sender
+// can no longer afford them against a simulated running balance.
 func mineBlock(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
-	var selectedIDs []string
-	if err := json.NewDecoder(r.Body).Decode(&selectedIDs); err != nil {
+
+	var req mineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid JSON body, expecting an array of transaction IDs"})
+		json.NewEncoder(w).Encode(map[string]string{"error": `Invalid JSON body, expecting {"miner": "<address>", "transaction_ids": [...]} (transaction_ids may be omitted to auto-select)`})
+		return
+	}
+	if req.Miner == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "miner address is required"})
 		return
 	}
 
-	var transactionsToMine []Transaction
-	var remainingTransactions []Transaction
-	minedIDs := make(map[string]bool)
+	chainMu.Lock()
 
-	// Select transactions based on IDs and remove them from the pending pool
-	for _, tx := range pendingTransactions {
-		found := false
-		for _, id := range selectedIDs {
-			if tx.ID == id {
-				transactionsToMine = append(transactionsToMine, tx)
-				minedIDs[id] = true
-				found = true
-				break
+	var candidates []Transaction
+	if len(req.TransactionIDs) == 0 {
+		candidates = append(candidates, pendingTransactions...)
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return gasPerByte(candidates[i]).Cmp(gasPerByte(candidates[j])) > 0
+		})
+	} else {
+		wanted := make(map[string]bool, len(req.TransactionIDs))
+		for _, id := range req.TransactionIDs {
+			wanted[id] = true
+		}
+		for _, tx := range pendingTransactions {
+			if wanted[tx.ID] {
+				candidates = append(candidates, tx)
 			}
 		}
-		if !found {
-			remainingTransactions = append(remainingTransactions, tx)
+	}
+
+	workingBalances := cloneBalances(blockchain.Balances)
+	var transactionsToMine []Transaction
+	mined := make(map[string]bool)
+	for _, tx := range candidates {
+		if tx.From == "system" {
+			continue // "system" is reserved for the genesis transaction and the miner reward, never a minable client tx
+		}
+		if !tx.Verify() || !affordable(workingBalances, tx) {
+			continue
 		}
+		debit(workingBalances, tx)
+		transactionsToMine = append(transactionsToMine, tx)
+		mined[tx.ID] = true
 	}
 
 	if len(transactionsToMine) == 0 {
+		chainMu.Unlock()
 		w.WriteHeader(http.StatusBadRequest)
 		json.NewEncoder(w).Encode(map[string]string{"error": "No valid transactions selected to mine"})
 		return
 	}
 
-	blockchain.AddBlock(transactionsToMine)
+	var remainingTransactions []Transaction
+	for _, tx := range pendingTransactions {
+		if !mined[tx.ID] {
+			remainingTransactions = append(remainingTransactions, tx)
+		}
+	}
+
+	if err := blockchain.AddBlock(transactionsToMine, req.Miner); err != nil {
+		chainMu.Unlock()
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to add block: " + err.Error()})
+		return
+	}
 	pendingTransactions = remainingTransactions // Update the pending pool
 
+	minedBlock := blockchain.Blocks[len(blockchain.Blocks)-1]
+	chainMu.Unlock()
+
+	broadcastBlock(minedBlock)
+
 	response := map[string]interface{}{
 		"message": "Block mined successfully",
-		"block":   blockchain.Blocks[len(blockchain.Blocks)-1],
+		"block":   minedBlock,
 	}
 	json.NewEncoder(w).Encode(response)
 }
 
 func getBlockchain(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+
+	chainMu.Lock()
+	defer chainMu.Unlock()
 	json.NewEncoder(w).Encode(blockchain)
 }
 
@@ -325,7 +614,10 @@ func searchBlockchain(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	chainMu.Lock()
 	results := blockchain.SearchBlockchain(query)
+	chainMu.Unlock()
+
 	json.NewEncoder(w).Encode(map[string]interface{}{
 		"query":   query,
 		"results": results,
@@ -336,26 +628,61 @@ func searchBlockchain(w http.ResponseWriter, r *http.Request) {
 func getBlockchainStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
+	chainMu.Lock()
 	status := map[string]interface{}{
 		"blocks":           len(blockchain.Blocks),
 		"difficulty":       blockchain.Difficulty,
 		"is_valid":         blockchain.IsChainValid(),
 		"pending_tx_count": len(pendingTransactions),
 	}
+	chainMu.Unlock()
 
 	json.NewEncoder(w).Encode(status)
 }
 
 func main() {
-	// Initialize blockchain with genesis block
-	blockchain = Blockchain{
-		Blocks:     []Block{CreateGenesisBlock()},
-		Difficulty: 4,
+	dbPath := flag.String("db", "./data/chaindb", "path to the chain's LevelDB directory")
+	listenAddr := flag.String("listen", "/ip4/0.0.0.0/tcp/4001", "multiaddr the p2p host listens on")
+	bootstrap := flag.String("bootstrap", "", "comma-separated multiaddrs of peers to dial on startup")
+	identityPath := flag.String("identity", "./data/peer.key", "path to persist this node's p2p identity")
+	consensusFlag := flag.String("consensus", "pow", "consensus engine: pow|pos")
+	flag.Parse()
+
+	var err error
+	store, err = storage.Open(*dbPath)
+	if err != nil {
+		log.Fatalf("failed to open chain store at %s: %v", *dbPath, err)
+	}
+	defer store.Close()
+
+	activeEngine = newConsensusEngine(*consensusFlag, 4)
+
+	// Boot from disk if a previous run left a chain behind, otherwise start fresh.
+	if chain, ok := loadBlockchain(); ok {
+		blockchain = chain
+		fmt.Printf("Muneeb's Blockchain restored from %s (%d blocks)\n", *dbPath, len(blockchain.Blocks))
+	} else {
+		genesis := CreateGenesisBlock()
+		if err := persistBlock(genesis); err != nil {
+			log.Fatalf("failed to persist genesis block: %v", err)
+		}
+		blockchain = Blockchain{
+			Blocks:     []Block{genesis},
+			Difficulty: 4,
+		}
+		blockchain.RebuildBalances()
+		fmt.Println("Muneeb's Blockchain initialized with genesis block")
 	}
 
-	fmt.Println("Muneeb's Blockchain initialized with genesis block")
 	fmt.Printf("Genesis block hash: %s\n", blockchain.Blocks[0].Hash)
 
+	node, err := startP2P(*listenAddr, *identityPath, parseBootstrapPeers(*bootstrap))
+	if err != nil {
+		log.Fatalf("failed to start p2p network: %v", err)
+	}
+	p2pNode = node
+	defer p2pNode.Close()
+
 	// Setup routes
 	router := mux.NewRouter()
 
@@ -367,6 +694,13 @@ func main() {
 	api.HandleFunc("/pending", getPendingTransactions).Methods("GET")
 	api.HandleFunc("/search", searchBlockchain).Methods("GET")
 	api.HandleFunc("/status", getBlockchainStatus).Methods("GET")
+	api.HandleFunc("/wallet/new", newWallet).Methods("POST")
+	api.HandleFunc("/wallet/sign", signTransaction).Methods("POST")
+	api.HandleFunc("/balance/{address}", getBalance).Methods("GET")
+	api.HandleFunc("/mempool/stats", getMempoolStats).Methods("GET")
+	api.HandleFunc("/tx/{id}/proof", getTxProof).Methods("GET")
+	api.HandleFunc("/headers", getHeaders).Methods("GET")
+	api.HandleFunc("/consensus/validators", registerValidator).Methods("POST")
 
 	// Setup CORS
 	c := cors.New(cors.Options{
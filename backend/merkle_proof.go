@@ -0,0 +1,88 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ProofNode is one step of a Merkle inclusion proof: the sibling hash to
+// combine with the running hash, and which side it sits on.
+type ProofNode struct {
+	Hash string `json:"hash"`
+	Left bool   `json:"left"` // true if Hash is the left operand when combining
+}
+
+// LeafHash returns the Merkle leaf hash for tx, i.e. sha256 of its JSON
+// encoding - the same thing NewMerkleNode computes for a leaf.
+func LeafHash(tx Transaction) string {
+	data, _ := json.Marshal(tx)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateProof returns the sibling hashes from txID's leaf up to the root,
+// in bottom-to-top order.
+func (mt *MerkleTree) GenerateProof(txID string) ([]ProofNode, error) {
+	index := -1
+	for i, tx := range mt.Transactions {
+		if tx.ID == txID {
+			index = i
+			break
+		}
+	}
+	if index == -1 {
+		return nil, fmt.Errorf("merkle: transaction %s not in tree", txID)
+	}
+
+	var proof []ProofNode
+	for _, level := range mt.levels[:len(mt.levels)-1] {
+		isRightNode := index%2 == 1
+
+		siblingIndex := index + 1
+		if isRightNode {
+			siblingIndex = index - 1
+		} else if siblingIndex >= len(level) {
+			// Odd level: the last node was duplicated against itself.
+			siblingIndex = index
+		}
+
+		proof = append(proof, ProofNode{
+			Hash: hex.EncodeToString(level[siblingIndex].Data),
+			Left: isRightNode,
+		})
+
+		index /= 2
+	}
+
+	return proof, nil
+}
+
+// VerifyProof recomputes the root from a leaf hash and its proof, and
+// compares it against the expected Merkle root.
+func VerifyProof(txHash, root string, proof []ProofNode) bool {
+	current, err := hex.DecodeString(txHash)
+	if err != nil {
+		return false
+	}
+
+	for _, step := range proof {
+		sibling, err := hex.DecodeString(step.Hash)
+		if err != nil {
+			return false
+		}
+
+		var combined []byte
+		if step.Left {
+			combined = append(append([]byte{}, sibling...), current...)
+		} else {
+			combined = append(append([]byte{}, current...), sibling...)
+		}
+
+		sum := sha256.Sum256(combined)
+		current = sum[:]
+	}
+
+	return hex.EncodeToString(current) == root
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Muhammadmuneebulhaq/MuneebBlockchain/backend/p2p"
+)
+
+var p2pNode *p2p.Node
+
+// chainAdapter implements p2p.ChainSync against the global blockchain. It
+// lives separately from Blockchain because the interface's Blocks() method
+// would otherwise collide with the Blocks field.
+type chainAdapter struct{}
+
+func (chainAdapter) Height() int {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	return blockchain.Blocks[len(blockchain.Blocks)-1].Index
+}
+
+func (chainAdapter) Blocks() ([][]byte, error) {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	encoded := make([][]byte, len(blockchain.Blocks))
+	for i, block := range blockchain.Blocks {
+		data, err := json.Marshal(block)
+		if err != nil {
+			return nil, err
+		}
+		encoded[i] = data
+	}
+	return encoded, nil
+}
+
+func (chainAdapter) ReplaceChain(raw [][]byte) error {
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	if len(raw) <= len(blockchain.Blocks) {
+		return nil // not longer, nothing to do
+	}
+
+	candidate := make([]Block, len(raw))
+	for i, data := range raw {
+		if err := json.Unmarshal(data, &candidate[i]); err != nil {
+			return fmt.Errorf("network: decode candidate chain: %w", err)
+		}
+	}
+
+	candidateChain := Blockchain{Blocks: candidate, Difficulty: blockchain.Difficulty}
+	if !candidateChain.IsChainValid() {
+		return fmt.Errorf("network: rejected candidate chain: invalid")
+	}
+
+	for _, block := range candidate {
+		if err := persistBlock(block); err != nil {
+			return fmt.Errorf("network: persist adopted block %d: %w", block.Index, err)
+		}
+	}
+
+	blockchain.Blocks = candidate
+	blockchain.RebuildBalances()
+	pendingTransactions = dropMinedTransactions(pendingTransactions, candidate)
+
+	fmt.Printf("network: adopted peer chain at height %d\n", candidateChain.Blocks[len(candidateChain.Blocks)-1].Index)
+	return nil
+}
+
+// blockGossip mirrors the envelope p2p expects on the dione/block topic:
+// the claimed height alongside the JSON-encoded block, so peers can decide
+// whether to run chain selection before decoding the body.
+type blockGossip struct {
+	Index int             `json:"index"`
+	Block json.RawMessage `json:"block"`
+}
+
+// startP2P joins the gossip network and wires incoming tx/block messages
+// into the local mempool and chain.
+func startP2P(listenAddr, identityPath string, bootstrapPeers []string) (*p2p.Node, error) {
+	node, err := p2p.New(context.Background(), p2p.Config{
+		ListenAddr:     listenAddr,
+		BootstrapPeers: bootstrapPeers,
+		IdentityPath:   identityPath,
+		Chain:          chainAdapter{},
+		OnTx:           onGossipTx,
+		OnBlock:        onGossipBlock,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("p2p: listening as %s\n", node.ID())
+	for _, addr := range node.Addrs() {
+		fmt.Printf("p2p: reachable at %s/p2p/%s\n", addr, node.ID())
+	}
+	return node, nil
+}
+
+func parseBootstrapPeers(flag string) []string {
+	if flag == "" {
+		return nil
+	}
+	var peers []string
+	for _, addr := range strings.Split(flag, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			peers = append(peers, addr)
+		}
+	}
+	return peers
+}
+
+// onGossipTx merges a transaction received from a peer into the pending
+// pool, skipping ones we've already seen.
+func onGossipTx(data []byte) {
+	var tx Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return
+	}
+
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	for _, existing := range pendingTransactions {
+		if existing.ID == tx.ID {
+			return
+		}
+	}
+	pendingTransactions = append(pendingTransactions, tx)
+}
+
+// onGossipBlock fast-paths a block that directly extends our tip; blocks
+// that leave a gap are instead picked up by p2p's own chain-selection sync.
+// The block's seal and every one of its transactions are checked exactly
+// like a locally mined block would be (see mineBlock) before it's applied,
+// so a malicious or buggy peer can't gossip its way into minting balances.
+func onGossipBlock(data []byte) {
+	var gossip blockGossip
+	if err := json.Unmarshal(data, &gossip); err != nil {
+		return
+	}
+
+	var block Block
+	if err := json.Unmarshal(gossip.Block, &block); err != nil {
+		return
+	}
+
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	tip := blockchain.Blocks[len(blockchain.Blocks)-1]
+	if block.Index != tip.Index+1 || block.PrevHash != tip.Hash {
+		return
+	}
+	if !verifyBlockSeal(&block) {
+		return
+	}
+
+	workingBalances := cloneBalances(blockchain.Balances)
+	for _, tx := range block.Transactions {
+		if tx.From == "system" {
+			// "system" is reserved for the genesis transaction, which is
+			// never gossiped (this is the tip-extension path for index > 0).
+			return
+		}
+		if !tx.Verify() || !affordable(workingBalances, tx) {
+			return
+		}
+		debit(workingBalances, tx)
+	}
+
+	if err := persistBlock(block); err != nil {
+		fmt.Printf("p2p: failed to persist gossiped block %d: %v\n", block.Index, err)
+		return
+	}
+
+	blockchain.Blocks = append(blockchain.Blocks, block)
+	blockchain.ApplyBlock(block)
+	pendingTransactions = dropMinedTransactions(pendingTransactions, []Block{block})
+}
+
+// dropMinedTransactions removes any pending transaction that now appears in
+// one of the given blocks.
+func dropMinedTransactions(pending []Transaction, blocks []Block) []Transaction {
+	mined := make(map[string]bool)
+	for _, block := range blocks {
+		for _, tx := range block.Transactions {
+			mined[tx.ID] = true
+		}
+	}
+
+	var remaining []Transaction
+	for _, tx := range pending {
+		if !mined[tx.ID] {
+			remaining = append(remaining, tx)
+		}
+	}
+	return remaining
+}
+
+// broadcastBlock gossips a freshly mined block to the network, if p2p is
+// enabled for this node.
+func broadcastBlock(block Block) {
+	if p2pNode == nil {
+		return
+	}
+	raw, err := json.Marshal(block)
+	if err != nil {
+		return
+	}
+	envelope, err := json.Marshal(blockGossip{Index: block.Index, Block: raw})
+	if err != nil {
+		return
+	}
+	if err := p2pNode.PublishBlock(context.Background(), envelope); err != nil {
+		fmt.Printf("p2p: failed to publish block %d: %v\n", block.Index, err)
+	}
+}
+
+// broadcastTx gossips a newly submitted transaction, if p2p is enabled.
+func broadcastTx(tx Transaction) {
+	if p2pNode == nil {
+		return
+	}
+	data, err := json.Marshal(tx)
+	if err != nil {
+		return
+	}
+	if err := p2pNode.PublishTx(context.Background(), data); err != nil {
+		fmt.Printf("p2p: failed to publish transaction %s: %v\n", tx.ID, err)
+	}
+}
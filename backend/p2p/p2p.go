@@ -0,0 +1,301 @@
+// Package p2p turns the node into a participant in a gossip network built
+// on go-libp2p and libp2p-pubsub. Two topics carry chain traffic:
+//
+//	dione/tx    - newly submitted transactions, merged into the local mempool
+//	dione/block - newly mined blocks, broadcast to every peer
+//
+// A lightweight request/response protocol ("/dione/sync/1.0.0") lets a node
+// that falls behind ask a peer for the blocks it's missing and adopt the
+// peer's chain when it turns out to be longer and valid (the usual
+// longest-valid-chain rule).
+package p2p
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/multiformats/go-multiaddr"
+)
+
+const (
+	txTopicName    = "dione/tx"
+	blockTopicName = "dione/block"
+	syncProtocol   = "/dione/sync/1.0.0"
+)
+
+// ChainSync is implemented by the blockchain so p2p can ask "how far along
+// are you", validate a candidate chain, and swap it in without importing
+// the blockchain package (which would create an import cycle).
+type ChainSync interface {
+	// Height returns the index of the local tip block.
+	Height() int
+	// Blocks returns the full local chain, JSON-encoded block per entry,
+	// in height order. Used to answer sync requests from peers.
+	Blocks() ([][]byte, error)
+	// ReplaceChain validates a candidate chain (JSON-encoded blocks, in
+	// height order) and, if it is longer and valid, adopts it.
+	ReplaceChain(blocks [][]byte) error
+}
+
+// Config configures a Node.
+type Config struct {
+	ListenAddr     string   // multiaddr to listen on, e.g. "/ip4/0.0.0.0/tcp/4001"
+	BootstrapPeers []string // multiaddrs of peers to dial on startup
+	IdentityPath   string   // file to persist/load the node's peer private key
+	Chain          ChainSync
+	OnTx           func(data []byte) // called with a gossiped transaction
+	OnBlock        func(data []byte) // called with a gossiped block
+}
+
+// Node is a running libp2p participant in the Dione gossip network.
+type Node struct {
+	host       host.Host
+	ps         *pubsub.PubSub
+	txTopic    *pubsub.Topic
+	blockTopic *pubsub.Topic
+	chain      ChainSync
+	onTx       func(data []byte)
+	onBlock    func(data []byte)
+}
+
+// New creates a libp2p host, joins the tx/block topics, and registers the
+// sync protocol handler. Callers should call Bootstrap afterwards to dial
+// any configured bootstrap peers.
+func New(ctx context.Context, cfg Config) (*Node, error) {
+	priv, err := loadOrCreateIdentity(cfg.IdentityPath)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: identity: %w", err)
+	}
+
+	opts := []libp2p.Option{libp2p.Identity(priv)}
+	if cfg.ListenAddr != "" {
+		opts = append(opts, libp2p.ListenAddrStrings(cfg.ListenAddr))
+	}
+
+	h, err := libp2p.New(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: new host: %w", err)
+	}
+
+	ps, err := pubsub.NewGossipSub(ctx, h)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: new gossipsub: %w", err)
+	}
+
+	txTopic, err := ps.Join(txTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: join %s: %w", txTopicName, err)
+	}
+	blockTopic, err := ps.Join(blockTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("p2p: join %s: %w", blockTopicName, err)
+	}
+
+	n := &Node{
+		host:       h,
+		ps:         ps,
+		txTopic:    txTopic,
+		blockTopic: blockTopic,
+		chain:      cfg.Chain,
+		onTx:       cfg.OnTx,
+		onBlock:    cfg.OnBlock,
+	}
+
+	h.SetStreamHandler(syncProtocol, n.handleSyncStream)
+
+	if err := n.subscribeLoop(ctx, txTopic, n.handleTx); err != nil {
+		return nil, err
+	}
+	if err := n.subscribeLoop(ctx, blockTopic, n.handleBlock); err != nil {
+		return nil, err
+	}
+
+	for _, addr := range cfg.BootstrapPeers {
+		if err := n.dial(ctx, addr); err != nil {
+			fmt.Printf("p2p: failed to dial bootstrap peer %s: %v\n", addr, err)
+		}
+	}
+
+	return n, nil
+}
+
+// ID returns the node's peer ID.
+func (n *Node) ID() peer.ID {
+	return n.host.ID()
+}
+
+// Addrs returns the multiaddrs this node is reachable on.
+func (n *Node) Addrs() []multiaddr.Multiaddr {
+	return n.host.Addrs()
+}
+
+// Close shuts the host down.
+func (n *Node) Close() error {
+	return n.host.Close()
+}
+
+// PublishTx gossips a newly submitted transaction to the dione/tx topic.
+func (n *Node) PublishTx(ctx context.Context, data []byte) error {
+	return n.txTopic.Publish(ctx, data)
+}
+
+// PublishBlock gossips a freshly mined block to the dione/block topic.
+func (n *Node) PublishBlock(ctx context.Context, data []byte) error {
+	return n.blockTopic.Publish(ctx, data)
+}
+
+func (n *Node) dial(ctx context.Context, addr string) error {
+	maddr, err := multiaddr.NewMultiaddr(addr)
+	if err != nil {
+		return err
+	}
+	info, err := peer.AddrInfoFromP2pAddr(maddr)
+	if err != nil {
+		return err
+	}
+	n.host.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
+	return n.host.Connect(ctx, *info)
+}
+
+func (n *Node) subscribeLoop(ctx context.Context, topic *pubsub.Topic, handle func([]byte, peer.ID)) error {
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return err
+	}
+	go func() {
+		for {
+			msg, err := sub.Next(ctx)
+			if err != nil {
+				return
+			}
+			if msg.ReceivedFrom == n.host.ID() {
+				continue
+			}
+			handle(msg.Data, msg.ReceivedFrom)
+		}
+	}()
+	return nil
+}
+
+func (n *Node) handleTx(data []byte, _ peer.ID) {
+	if n.onTx != nil {
+		n.onTx(data)
+	}
+}
+
+// blockGossip is what's actually published on dione/block: the mined block
+// plus the height it claims, so peers can cheaply decide whether they need
+// to run chain selection before even decoding the block body.
+type blockGossip struct {
+	Index int             `json:"index"`
+	Block json.RawMessage `json:"block"`
+}
+
+func (n *Node) handleBlock(data []byte, from peer.ID) {
+	if n.onBlock != nil {
+		n.onBlock(data)
+	}
+
+	var gossip blockGossip
+	if err := json.Unmarshal(data, &gossip); err != nil {
+		return
+	}
+
+	if n.chain == nil || gossip.Index <= n.chain.Height() {
+		return
+	}
+
+	// The peer claims a chain longer than ours - pull it and switch if it
+	// turns out to be valid.
+	if err := n.syncFrom(from); err != nil {
+		fmt.Printf("p2p: chain sync with %s failed: %v\n", from, err)
+	}
+}
+
+// syncFrom requests the peer's full chain over the sync protocol and hands
+// it to ChainSync.ReplaceChain, which is responsible for validating it and
+// only adopting it if it is both valid and longer (the chain-selection
+// rule).
+func (n *Node) syncFrom(p peer.ID) error {
+	stream, err := n.host.NewStream(context.Background(), p, syncProtocol)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	raw, err := io.ReadAll(bufio.NewReader(stream))
+	if err != nil {
+		return err
+	}
+
+	var blocks [][]byte
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return err
+	}
+
+	return n.chain.ReplaceChain(blocks)
+}
+
+// handleSyncStream answers a peer's request for our chain by writing back
+// every block we have, JSON-encoded, in height order.
+func (n *Node) handleSyncStream(stream network.Stream) {
+	defer stream.Close()
+
+	if n.chain == nil {
+		return
+	}
+
+	blocks, err := n.chain.Blocks()
+	if err != nil {
+		return
+	}
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		return
+	}
+
+	stream.Write(data)
+}
+
+// loadOrCreateIdentity loads a persisted libp2p private key from path, or
+// generates and saves a new one if none exists yet, so the node's peer ID
+// is stable across restarts.
+func loadOrCreateIdentity(path string) (crypto.PrivKey, error) {
+	if path == "" {
+		priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		return priv, err
+	}
+
+	if data, err := os.ReadFile(path); err == nil {
+		return crypto.UnmarshalPrivateKey(data)
+	}
+
+	priv, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := crypto.MarshalPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+
+	return priv, nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// BlockHeader is the header-only view of a block: everything needed to
+// verify a Merkle proof and walk the chain, without shipping transaction
+// bodies. This is what a light (SPV) client downloads instead of full blocks.
+type BlockHeader struct {
+	Index      int    `json:"index"`
+	Timestamp  int64  `json:"timestamp"`
+	PrevHash   string `json:"prev_hash"`
+	Hash       string `json:"hash"`
+	MerkleRoot string `json:"merkle_root"`
+	Miner      string `json:"miner"`
+}
+
+func headerOf(block Block) BlockHeader {
+	return BlockHeader{
+		Index:      block.Index,
+		Timestamp:  block.Timestamp,
+		PrevHash:   block.PrevHash,
+		Hash:       block.Hash,
+		MerkleRoot: block.MerkleRoot,
+		Miner:      block.Miner,
+	}
+}
+
+// findBlockForTx returns the block containing txID, preferring the store's
+// tx index over scanning the in-memory chain.
+func findBlockForTx(txID string) (Block, bool) {
+	if store != nil {
+		if hash, err := store.BlockHashForTx(txID); err == nil {
+			if data, err := store.GetBlock(hash); err == nil {
+				var block Block
+				if json.Unmarshal(data, &block) == nil {
+					return block, true
+				}
+			}
+		}
+	}
+
+	chainMu.Lock()
+	defer chainMu.Unlock()
+	for _, block := range blockchain.Blocks {
+		for _, tx := range block.Transactions {
+			if tx.ID == txID {
+				return block, true
+			}
+		}
+	}
+
+	return Block{}, false
+}
+
+// getTxProof handler returns a Merkle inclusion proof for a transaction, so
+// a light client can verify it's in the chain without downloading the
+// full block.
+func getTxProof(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	txID := mux.Vars(r)["id"]
+	block, found := findBlockForTx(txID)
+	if !found {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Transaction not found"})
+		return
+	}
+
+	var tx Transaction
+	for _, candidate := range block.Transactions {
+		if candidate.ID == txID {
+			tx = candidate
+			break
+		}
+	}
+
+	tree := NewMerkleTree(block.Transactions)
+	proof, err := tree.GenerateProof(txID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"block_hash":  block.Hash,
+		"merkle_root": block.MerkleRoot,
+		"tx":          tx,
+		"proof":       proof,
+	})
+}
+
+// getHeaders handler returns headers (no transaction bodies) for the
+// inclusive block-index range [from, to], defaulting to the whole chain.
+func getHeaders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	chainMu.Lock()
+	defer chainMu.Unlock()
+
+	from := 0
+	to := len(blockchain.Blocks) - 1
+
+	if v := r.URL.Query().Get("from"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			from = parsed
+		}
+	}
+	if v := r.URL.Query().Get("to"); v != "" {
+		if parsed, err := strconv.Atoi(v); err == nil {
+			to = parsed
+		}
+	}
+	if from < 0 {
+		from = 0
+	}
+	if to > len(blockchain.Blocks)-1 {
+		to = len(blockchain.Blocks) - 1
+	}
+
+	var headers []BlockHeader
+	for i := from; i <= to && i < len(blockchain.Blocks); i++ {
+		headers = append(headers, headerOf(blockchain.Blocks[i]))
+	}
+
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"from":    from,
+		"to":      to,
+		"headers": headers,
+	})
+}
@@ -0,0 +1,164 @@
+package main
+
+import "math/big"
+
+// blockReward is the fixed subsidy paid to whoever mines a block, on top of
+// the gas fees collected from that block's transactions.
+var blockReward = big.NewInt(50)
+
+// parseUint parses Amount/GasFee strings as non-negative base-10 integers
+// (the chain's smallest unit, analogous to wei).
+func parseUint(s string) (*big.Int, bool) {
+	if s == "" {
+		return big.NewInt(0), true
+	}
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, false
+	}
+	return n, true
+}
+
+// balanceOf returns the balance for address in balances, defaulting to zero.
+func balanceOf(balances map[string]*big.Int, address string) *big.Int {
+	if balance, ok := balances[address]; ok {
+		return balance
+	}
+	return big.NewInt(0)
+}
+
+// affordable reports whether tx.From currently has enough balance to cover
+// Amount+GasFee. System transactions (genesis, mint) are exempt.
+func affordable(balances map[string]*big.Int, tx Transaction) bool {
+	if tx.From == "system" {
+		return true
+	}
+	amount, ok := parseUint(tx.Amount)
+	if !ok {
+		return false
+	}
+	gas, ok := parseUint(tx.GasFee)
+	if !ok {
+		return false
+	}
+	total := new(big.Int).Add(amount, gas)
+	return balanceOf(balances, tx.From).Cmp(total) >= 0
+}
+
+// debit applies tx to balances: From is debited by Amount+GasFee and To is
+// credited by Amount. A system transaction mints Amount directly into To.
+func debit(balances map[string]*big.Int, tx Transaction) {
+	amount, ok := parseUint(tx.Amount)
+	if !ok {
+		return
+	}
+
+	if tx.From != "system" {
+		gas, ok := parseUint(tx.GasFee)
+		if !ok {
+			return
+		}
+		total := new(big.Int).Add(amount, gas)
+		balances[tx.From] = new(big.Int).Sub(balanceOf(balances, tx.From), total)
+	}
+
+	balances[tx.To] = new(big.Int).Add(balanceOf(balances, tx.To), amount)
+}
+
+// cloneBalances returns a deep-enough copy of balances for simulating a
+// candidate block's transactions without mutating chain state.
+func cloneBalances(balances map[string]*big.Int) map[string]*big.Int {
+	clone := make(map[string]*big.Int, len(balances))
+	for address, balance := range balances {
+		clone[address] = new(big.Int).Set(balance)
+	}
+	return clone
+}
+
+// balanceOf returns the account balance for address, defaulting to zero.
+func (bc *Blockchain) balanceOf(address string) *big.Int {
+	return balanceOf(bc.Balances, address)
+}
+
+// ApplyBlock applies every transaction in block to the account-state layer,
+// then credits block.Miner with the block reward plus the gas fees
+// collected from the block's transactions. Validator registrations are
+// replayed the same way (see applyValidatorRegistration) so the PoS
+// validator set stays in sync with the chain itself rather than drifting
+// from one node's in-memory state.
+func (bc *Blockchain) ApplyBlock(block Block) {
+	if bc.Balances == nil {
+		bc.Balances = make(map[string]*big.Int)
+	}
+
+	gasCollected := big.NewInt(0)
+	for _, tx := range block.Transactions {
+		debit(bc.Balances, tx)
+		applyValidatorRegistration(tx)
+		if tx.From != "system" {
+			if gas, ok := parseUint(tx.GasFee); ok {
+				gasCollected.Add(gasCollected, gas)
+			}
+		}
+	}
+
+	if block.Miner != "" && block.Miner != "system" {
+		reward := new(big.Int).Add(blockReward, gasCollected)
+		bc.Balances[block.Miner] = new(big.Int).Add(bc.balanceOf(block.Miner), reward)
+	}
+}
+
+// validateChainBalances replays blocks from a zero balance sheet and
+// reports whether every transaction was affordable against the running
+// balance at the time it appears - i.e. whether the chain is a legitimate
+// history rather than one that credits accounts out of nothing. Block
+// rewards and collected gas fees are credited to the miner exactly like
+// ApplyBlock does, so a miner spending an earlier block's reward or gas
+// proceeds is correctly seen as affordable.
+func validateChainBalances(blocks []Block) bool {
+	balances := make(map[string]*big.Int)
+	for _, block := range blocks {
+		gasCollected := big.NewInt(0)
+		for _, tx := range block.Transactions {
+			if !affordable(balances, tx) {
+				return false
+			}
+			debit(balances, tx)
+			if tx.From != "system" {
+				if gas, ok := parseUint(tx.GasFee); ok {
+					gasCollected.Add(gasCollected, gas)
+				}
+			}
+		}
+
+		if block.Miner != "" && block.Miner != "system" {
+			reward := new(big.Int).Add(blockReward, gasCollected)
+			balances[block.Miner] = new(big.Int).Add(balanceOf(balances, block.Miner), reward)
+		}
+	}
+	return true
+}
+
+// RebuildBalances replays every block from scratch to reconstruct the
+// account-state layer, used after loading the chain from disk or adopting a
+// peer's longer chain.
+func (bc *Blockchain) RebuildBalances() {
+	bc.Balances = make(map[string]*big.Int)
+	for _, block := range bc.Blocks {
+		bc.ApplyBlock(block)
+	}
+}
+
+// gasPerByte is used to rank pending transactions for mining: higher
+// GasFee/size wins, mirroring how Ethereum's mempool prioritizes gas price.
+func gasPerByte(tx Transaction) *big.Rat {
+	gas, ok := parseUint(tx.GasFee)
+	if !ok {
+		gas = big.NewInt(0)
+	}
+	size := len(tx.ID) + len(tx.From) + len(tx.To) + len(tx.Amount) + len(tx.Data) + len(tx.GasFee)
+	if size == 0 {
+		size = 1
+	}
+	return new(big.Rat).SetFrac(gas, big.NewInt(int64(size)))
+}
@@ -0,0 +1,122 @@
+// Package storage provides a LevelDB-backed persistence layer for the
+// blockchain so that blocks and transactions survive a node restart.
+//
+// Keys follow a simple schema borrowed from the usual chain-DB layout
+// (blockchain-poc, go-ethereum):
+//
+//	b:<hash>        -> JSON-encoded block
+//	n:<index>       -> block hash at that height
+//	tx:<id>         -> hash of the block containing that transaction
+//	l               -> hash of the latest (tip) block
+package storage
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ErrNotFound is returned when a lookup key does not exist in the store.
+var ErrNotFound = errors.New("storage: key not found")
+
+const (
+	blockPrefix = "b:"
+	indexPrefix = "n:"
+	txPrefix    = "tx:"
+	latestKey   = "l"
+)
+
+// Store wraps a LevelDB handle with the chain's key schema.
+type Store struct {
+	db *leveldb.DB
+}
+
+// Open opens (creating if necessary) the LevelDB database at path.
+func Open(path string) (*Store, error) {
+	db, err := leveldb.OpenFile(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Store{db: db}, nil
+}
+
+// Close releases the underlying database handle.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+func wrapErr(err error) error {
+	if errors.Is(err, leveldb.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// SaveBlock writes the JSON-encoded block under b:<hash>, indexes it under
+// n:<index>, records each transaction's blockHash under tx:<id>, and moves
+// the tip pointer (l) forward.
+func (s *Store) SaveBlock(hash string, index int, txIDs []string, data []byte) error {
+	batch := new(leveldb.Batch)
+	batch.Put([]byte(blockPrefix+hash), data)
+	batch.Put([]byte(indexPrefix+strconv.Itoa(index)), []byte(hash))
+	for _, id := range txIDs {
+		batch.Put([]byte(txPrefix+id), []byte(hash))
+	}
+	batch.Put([]byte(latestKey), []byte(hash))
+	return s.db.Write(batch, nil)
+}
+
+// GetBlock returns the raw JSON for the block with the given hash.
+func (s *Store) GetBlock(hash string) ([]byte, error) {
+	data, err := s.db.Get([]byte(blockPrefix+hash), nil)
+	if err != nil {
+		return nil, wrapErr(err)
+	}
+	return data, nil
+}
+
+// HashByIndex returns the hash of the block at the given height.
+func (s *Store) HashByIndex(index int) (string, error) {
+	data, err := s.db.Get([]byte(indexPrefix+strconv.Itoa(index)), nil)
+	if err != nil {
+		return "", wrapErr(err)
+	}
+	return string(data), nil
+}
+
+// BlockHashForTx returns the hash of the block that contains the given
+// transaction ID, without scanning the chain.
+func (s *Store) BlockHashForTx(txID string) (string, error) {
+	data, err := s.db.Get([]byte(txPrefix+txID), nil)
+	if err != nil {
+		return "", wrapErr(err)
+	}
+	return string(data), nil
+}
+
+// LatestHash returns the hash of the current tip block.
+func (s *Store) LatestHash() (string, error) {
+	data, err := s.db.Get([]byte(latestKey), nil)
+	if err != nil {
+		return "", wrapErr(err)
+	}
+	return string(data), nil
+}
+
+// AllBlocks returns the raw JSON of every stored block, in the order
+// LevelDB iterates the b: prefix. Callers that need chain order should
+// walk n:0, n:1, ... via HashByIndex/GetBlock instead.
+func (s *Store) AllBlocks() ([][]byte, error) {
+	iter := s.db.NewIterator(util.BytesPrefix([]byte(blockPrefix)), nil)
+	defer iter.Release()
+
+	var blocks [][]byte
+	for iter.Next() {
+		value := make([]byte, len(iter.Value()))
+		copy(value, iter.Value())
+		blocks = append(blocks, value)
+	}
+	return blocks, iter.Error()
+}
@@ -0,0 +1,85 @@
+// Package wallet provides secp256k1 keypairs for signing transactions.
+// An address is derived as SHA-256(compressed pubkey), and transactions are
+// signed over their canonical serialization (see Transaction.Hash in the
+// main package) rather than the struct as a whole, so that adding the
+// PubKey/Signature fields themselves doesn't change what gets signed.
+package wallet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// Wallet holds a single secp256k1 keypair.
+type Wallet struct {
+	PrivateKey *btcec.PrivateKey
+}
+
+// New generates a fresh secp256k1 keypair.
+func New() (*Wallet, error) {
+	priv, err := btcec.NewPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &Wallet{PrivateKey: priv}, nil
+}
+
+// FromPrivateKeyHex rebuilds a Wallet from a hex-encoded private key, e.g.
+// one previously returned by POST /api/wallet/new.
+func FromPrivateKeyHex(hexKey string) (*Wallet, error) {
+	raw, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return nil, err
+	}
+	priv, _ := btcec.PrivKeyFromBytes(raw)
+	return &Wallet{PrivateKey: priv}, nil
+}
+
+// PrivateKeyHex returns the hex-encoded private key.
+func (w *Wallet) PrivateKeyHex() string {
+	return hex.EncodeToString(w.PrivateKey.Serialize())
+}
+
+// PublicKeyBytes returns the compressed SEC1 public key.
+func (w *Wallet) PublicKeyBytes() []byte {
+	return w.PrivateKey.PubKey().SerializeCompressed()
+}
+
+// PublicKeyHex returns the compressed public key, hex-encoded.
+func (w *Wallet) PublicKeyHex() string {
+	return hex.EncodeToString(w.PublicKeyBytes())
+}
+
+// Address derives an address from a compressed public key as
+// hex(SHA-256(pubkey)).
+func Address(pubKey []byte) string {
+	sum := sha256.Sum256(pubKey)
+	return hex.EncodeToString(sum[:])
+}
+
+// Address returns this wallet's address.
+func (w *Wallet) Address() string {
+	return Address(w.PublicKeyBytes())
+}
+
+// Sign produces a DER-encoded ECDSA signature over hash.
+func (w *Wallet) Sign(hash []byte) []byte {
+	return ecdsa.Sign(w.PrivateKey, hash).Serialize()
+}
+
+// Verify checks a DER-encoded ECDSA signature over hash against a
+// compressed public key.
+func Verify(pubKey, hash, sig []byte) bool {
+	key, err := btcec.ParsePubKey(pubKey)
+	if err != nil {
+		return false
+	}
+	signature, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return false
+	}
+	return signature.Verify(hash, key)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Muhammadmuneebulhaq/MuneebBlockchain/backend/wallet"
+)
+
+// newWallet handler generates a fresh keypair and returns it to the caller.
+func newWallet(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	wlt, err := wallet.New()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Failed to generate wallet: " + err.Error()})
+		return
+	}
+
+	json.NewEncoder(w).Encode(map[string]string{
+		"address":     wlt.Address(),
+		"public_key":  wlt.PublicKeyHex(),
+		"private_key": wlt.PrivateKeyHex(),
+	})
+}
+
+// signTransactionRequest is the body expected by POST /api/wallet/sign.
+type signTransactionRequest struct {
+	PrivateKey  string      `json:"private_key"`
+	Transaction Transaction `json:"transaction"`
+}
+
+// signTransaction handler signs a transaction with the given private key
+// and returns it with PubKey/Signature filled in, ready to submit to
+// POST /api/transactions.
+func signTransaction(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req signTransactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid JSON"})
+		return
+	}
+
+	wlt, err := wallet.FromPrivateKeyHex(req.PrivateKey)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Invalid private key: " + err.Error()})
+		return
+	}
+
+	tx := req.Transaction
+	tx.From = wlt.Address()
+	tx.PubKey = wlt.PublicKeyHex()
+	tx.Signature = hex.EncodeToString(wlt.Sign(tx.Hash()))
+
+	json.NewEncoder(w).Encode(tx)
+}